@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"text/template"
+)
+
+// setTemplate mirrors the core API of ordset.OrderedSet[T] (Len, Has, Front, Back, Append,
+// Prepend, Pop, Shift, Insert, Move, Remove, Range, RangeReverse and Slice), specialized for a
+// single concrete element type so that callers targeting Go <1.18, or who want to avoid
+// interface-conversion overhead, can generate a plain, non-generic equivalent.
+//
+// The generated type is backed by container/list rather than the order-statistic tree that
+// backs OrderedSet[T], and it does not include positional access (At, IndexOf, InsertAt,
+// RemoveAt), the set algebra methods, handles, or JSON, gob and text marshaling.
+var setTemplate = template.Must(template.New("ordset").Parse(`// Code generated by ordset-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"container/list"
+	"errors"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+// ErrMarkNotFound is returned by Insert and Move when the given mark value is not a member of
+// the {{.TypeName}}.
+var ErrMarkNotFound = errors.New("reference value for insert is not in the {{.TypeName}}")
+
+// {{.TypeName}} is a non-generic, code-generated equivalent of ordset.OrderedSet[{{.ElemType}}].
+type {{.TypeName}} struct {
+	list    *list.List
+	mapping map[{{.KeyType}}]*list.Element
+}
+
+// New{{.TypeName}} initializes a {{.TypeName}} with an initial set of elements.
+func New{{.TypeName}}(elems ...{{.ElemType}}) *{{.TypeName}} {
+	set := &{{.TypeName}}{
+		list:    list.New(),
+		mapping: make(map[{{.KeyType}}]*list.Element),
+	}
+	for _, v := range elems {
+		set.Append(v)
+	}
+	return set
+}
+
+// Len returns the number of elements in the {{.TypeName}}.
+func (o *{{.TypeName}}) Len() int {
+	return o.list.Len()
+}
+
+// Has returns true if the given value v is a member of the {{.TypeName}}.
+func (o *{{.TypeName}}) Has(v {{.ElemType}}) bool {
+	_, exists := o.mapping[{{.KeyExpr}}]
+	return exists
+}
+
+// Front returns the element at the front of the {{.TypeName}}.
+func (o *{{.TypeName}}) Front() {{.ElemType}} {
+	return o.list.Front().Value.({{.ElemType}})
+}
+
+// Back returns the element at the back of the {{.TypeName}}.
+func (o *{{.TypeName}}) Back() {{.ElemType}} {
+	return o.list.Back().Value.({{.ElemType}})
+}
+
+// Append pushes a value to the back of the {{.TypeName}}.
+func (o *{{.TypeName}}) Append(v {{.ElemType}}) bool {
+	if o.Has(v) {
+		return false
+	}
+	o.mapping[{{.KeyExpr}}] = o.list.PushBack(v)
+	return true
+}
+
+// Prepend pushes a value to the front of the {{.TypeName}}.
+func (o *{{.TypeName}}) Prepend(v {{.ElemType}}) bool {
+	if o.Has(v) {
+		return false
+	}
+	o.mapping[{{.KeyExpr}}] = o.list.PushFront(v)
+	return true
+}
+
+// Pop extracts and removes a value from the right of the {{.TypeName}}. Returns a boolean true
+// value if an element was successfully popped. This will only ever be false if the
+// {{.TypeName}} is empty.
+func (o *{{.TypeName}}) Pop() (v {{.ElemType}}, ok bool) {
+	if o.Len() > 0 {
+		elem := o.list.Back()
+		o.list.Remove(elem)
+		v, ok = elem.Value.({{.ElemType}})
+		delete(o.mapping, {{.KeyExpr}})
+	}
+	return
+}
+
+// Shift extracts and removes a value from the left of the {{.TypeName}}. Returns a boolean true
+// value if an element was successfully popped. This will only ever be false if the
+// {{.TypeName}} is empty.
+func (o *{{.TypeName}}) Shift() (v {{.ElemType}}, ok bool) {
+	if o.Len() > 0 {
+		elem := o.list.Front()
+		o.list.Remove(elem)
+		v, ok = elem.Value.({{.ElemType}})
+		delete(o.mapping, {{.KeyExpr}})
+	}
+	return
+}
+
+// Insert inserts the given value v into the {{.TypeName}} at a specific position relative to
+// the given mark value. If after is true, v is inserted immediately behind mark, otherwise
+// immediately in front of it.
+//
+// If v is already a member of the set, Insert is a no-op. Use Move to reorder set elements.
+func (o *{{.TypeName}}) Insert(v, mark {{.ElemType}}, after bool) (added bool, err error) {
+	markKey := func(v {{.ElemType}}) {{.KeyType}} { return {{.KeyExpr}} }(mark)
+	markElem, ok := o.mapping[markKey]
+	if !ok {
+		return false, ErrMarkNotFound
+	} else if o.Has(v) {
+		return false, nil
+	}
+
+	if after {
+		o.mapping[{{.KeyExpr}}] = o.list.InsertAfter(v, markElem)
+	} else {
+		o.mapping[{{.KeyExpr}}] = o.list.InsertBefore(v, markElem)
+	}
+	return true, nil
+}
+
+// Move repositions the set element value v relative to the given mark value. If after is true,
+// v is moved to immediately behind mark, otherwise immediately in front of it.
+func (o *{{.TypeName}}) Move(v, mark {{.ElemType}}, after bool) (err error) {
+	markKey := func(v {{.ElemType}}) {{.KeyType}} { return {{.KeyExpr}} }(mark)
+	markElem, ok := o.mapping[markKey]
+	if !ok {
+		return ErrMarkNotFound
+	}
+	if elem, ok := o.mapping[{{.KeyExpr}}]; ok {
+		if after {
+			o.list.MoveAfter(elem, markElem)
+		} else {
+			o.list.MoveBefore(elem, markElem)
+		}
+	}
+	return nil
+}
+
+// Remove removes the value v from the {{.TypeName}}, if present.
+func (o *{{.TypeName}}) Remove(v {{.ElemType}}) bool {
+	if elem, ok := o.mapping[{{.KeyExpr}}]; ok {
+		o.list.Remove(elem)
+		delete(o.mapping, {{.KeyExpr}})
+		return true
+	}
+	return false
+}
+
+// Range calls loop once for every element of the {{.TypeName}}, front to back, passing its
+// index and value. Range stops and returns the first non-nil error loop returns.
+func (o *{{.TypeName}}) Range(loop func(int, {{.ElemType}}) error) error {
+	i := 0
+	for elem := o.list.Front(); elem != nil; elem = elem.Next() {
+		if err := loop(i, elem.Value.({{.ElemType}})); err != nil {
+			return err
+		}
+		i++
+	}
+	return nil
+}
+
+// RangeReverse calls loop once for every element of the {{.TypeName}}, back to front, passing
+// its index and value. RangeReverse stops and returns the first non-nil error loop returns.
+func (o *{{.TypeName}}) RangeReverse(loop func(int, {{.ElemType}}) error) error {
+	i := 0
+	for elem := o.list.Back(); elem != nil; elem = elem.Prev() {
+		if err := loop(i, elem.Value.({{.ElemType}})); err != nil {
+			return err
+		}
+		i++
+	}
+	return nil
+}
+
+// Slice returns the elements of the {{.TypeName}} as a slice, front to back.
+func (o *{{.TypeName}}) Slice() []{{.ElemType}} {
+	slice := make([]{{.ElemType}}, 0, o.Len())
+	o.Range(func(_ int, v {{.ElemType}}) error {
+		slice = append(slice, v)
+		return nil
+	})
+	return slice
+}
+`))
+
+// generate renders spec through setTemplate and formats the result with gofmt.
+func generate(spec *Spec) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := setTemplate.Execute(&buf, spec); err != nil {
+		return nil, fmt.Errorf("executing template: %w", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated source: %w", err)
+	}
+	return formatted, nil
+}
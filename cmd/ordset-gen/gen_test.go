@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	specs := []string{"specs/string.json", "specs/int.json", "specs/bytes.json"}
+
+	for _, path := range specs {
+		t.Run(path, func(t *testing.T) {
+			spec, err := loadSpec(path)
+			if err != nil {
+				t.Errorf("failed to load spec: %s", err)
+				return
+			}
+
+			src, err := generate(spec)
+			if err != nil {
+				t.Errorf("failed to generate source: %s", err)
+				return
+			}
+
+			out := string(src)
+			if !strings.Contains(out, "package "+spec.Package) {
+				t.Errorf("generated source missing expected package clause: %s", out)
+				return
+			}
+			if !strings.Contains(out, "type "+spec.TypeName+" struct") {
+				t.Errorf("generated source missing expected type declaration: %s", out)
+				return
+			}
+			if !strings.Contains(out, "func New"+spec.TypeName+"(") {
+				t.Errorf("generated source missing expected constructor: %s", out)
+				return
+			}
+		})
+	}
+}
+
+// TestGenerateCompiles actually builds the generated source in a scratch module, rather than
+// just grepping it like TestGenerate does, so a template change that produces invalid Go fails
+// the test even if it happens to still contain the expected strings.
+func TestGenerateCompiles(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not found on PATH")
+	}
+
+	spec, err := loadSpec("specs/bytes.json")
+	if err != nil {
+		t.Fatalf("failed to load spec: %s", err)
+	}
+
+	src, err := generate(spec)
+	if err != nil {
+		t.Fatalf("failed to generate source: %s", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "generated.go"), src, 0o644); err != nil {
+		t.Fatalf("failed to write generated source: %s", err)
+	}
+	goMod := "module generated\n\ngo 1.21\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatalf("failed to write go.mod: %s", err)
+	}
+
+	cmd := exec.Command(goBin, "build", "./...")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("generated source for %s failed to compile: %s\n%s", spec.TypeName, err, out)
+	}
+}
+
+func TestLoadSpecDefaults(t *testing.T) {
+	spec, err := loadSpec("specs/string.json")
+	if err != nil {
+		t.Errorf("failed to load spec: %s", err)
+		return
+	}
+	if spec.KeyType != "string" || spec.KeyExpr != "v" {
+		t.Errorf("expected defaulted KeyType/KeyExpr, got %q/%q", spec.KeyType, spec.KeyExpr)
+		return
+	}
+}
@@ -0,0 +1,62 @@
+// Command ordset-gen emits a non-generic, allocation-optimized equivalent of
+// ordset.OrderedSet[T] for a single concrete element type, given a small JSON spec describing
+// the package, type name, element type and any imports it needs.
+//
+// This is useful for callers targeting Go versions without generics, or who want tight inlining
+// without interface-conversion overhead.
+//
+// Usage:
+//
+//	ordset-gen -spec spec.json -out stringset_gen.go
+//
+// A typical spec file looks like:
+//
+//	{
+//	  "Package": "stringset",
+//	  "TypeName": "StringOrderedSet",
+//	  "ElemType": "string"
+//	}
+//
+// Reference specs for string, int and []byte element types live under ./specs. To regenerate
+// one from a Go file in its target package:
+//
+//	//go:generate go run github.com/kklash/ordset/cmd/ordset-gen -spec specs/string.json -out stringset_gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "path to a JSON spec file describing the set to generate")
+	outPath := flag.String("out", "", "path to write the generated file to (defaults to stdout)")
+	flag.Parse()
+
+	if *specPath == "" {
+		fmt.Fprintln(os.Stderr, "ordset-gen: -spec is required")
+		os.Exit(1)
+	}
+
+	spec, err := loadSpec(*specPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ordset-gen: %s\n", err)
+		os.Exit(1)
+	}
+
+	src, err := generate(spec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ordset-gen: %s\n", err)
+		os.Exit(1)
+	}
+
+	if *outPath == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*outPath, src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "ordset-gen: %s\n", err)
+		os.Exit(1)
+	}
+}
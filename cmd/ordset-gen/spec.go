@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Spec describes one non-generic OrderedSet-equivalent to emit.
+type Spec struct {
+	// Package is the package name of the generated file.
+	Package string `json:"Package"`
+
+	// TypeName is the name of the generated set type, e.g. "StringOrderedSet".
+	TypeName string `json:"TypeName"`
+
+	// ElemType is the Go type of the elements stored in the set, e.g. "string" or "[]byte".
+	ElemType string `json:"ElemType"`
+
+	// Imports lists additional import paths ElemType depends on, e.g. "time" for a
+	// time.Time element type. "container/list" is always imported and need not be listed.
+	Imports []string `json:"Imports"`
+
+	// KeyType is the type used to key the internal map, for element types which are not
+	// themselves comparable (e.g. []byte). It defaults to ElemType.
+	KeyType string `json:"KeyType"`
+
+	// KeyExpr is a Go expression which converts a local variable named v of type ElemType
+	// into a value of type KeyType, used everywhere the set needs to key its map. It
+	// defaults to "v".
+	KeyExpr string `json:"KeyExpr"`
+}
+
+// loadSpec reads and validates a Spec from the JSON file at path, filling in defaults for
+// KeyType and KeyExpr when the element type is already its own key.
+func loadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parsing spec: %w", err)
+	}
+
+	if spec.Package == "" || spec.TypeName == "" || spec.ElemType == "" {
+		return nil, fmt.Errorf("spec must set Package, TypeName and ElemType")
+	}
+	if spec.KeyType == "" {
+		spec.KeyType = spec.ElemType
+	}
+	if spec.KeyExpr == "" {
+		spec.KeyExpr = "v"
+	}
+	return &spec, nil
+}
@@ -0,0 +1,71 @@
+package ordset
+
+// Handle is an opaque, comparable identifier for an element of an OrderedSet[T], returned by
+// AppendH and PrependH. Unlike the element's value, a Handle stays valid even if the value is
+// later mutated in place, which matters when T is a pointer-like type and the caller wants to
+// find or reposition that exact element by identity rather than by re-hashing its contents. A
+// Handle also survives a Move of its element, since Move repositions the underlying node rather
+// than replacing it.
+//
+// Internally a Handle just wraps the tree node backing the element, so resolving it back to a
+// value stays O(1) without ever needing to hash T. A Handle is only meaningful for the
+// OrderedSet it was obtained from; using it with a different OrderedSet is undefined.
+type Handle[T comparable] struct {
+	n *node[T]
+}
+
+// AppendH pushes v to the back of the OrderedSet and returns a Handle for it. If v is already a
+// member, AppendH is a no-op and returns the existing element's Handle.
+func (o *OrderedSet[T]) AppendH(v T) Handle[T] {
+	if n, ok := o.mapping[v]; ok {
+		return Handle[T]{n: n}
+	}
+	o.Append(v)
+	return Handle[T]{n: o.mapping[v]}
+}
+
+// PrependH pushes v to the front of the OrderedSet and returns a Handle for it. If v is already
+// a member, PrependH is a no-op and returns the existing element's Handle.
+func (o *OrderedSet[T]) PrependH(v T) Handle[T] {
+	if n, ok := o.mapping[v]; ok {
+		return Handle[T]{n: n}
+	}
+	o.Prepend(v)
+	return Handle[T]{n: o.mapping[v]}
+}
+
+// ValueOf returns the value identified by h and true, as long as h still refers to an element
+// currently in the OrderedSet. It returns the zero value and false if the element behind h has
+// since been removed.
+func (o *OrderedSet[T]) ValueOf(h Handle[T]) (v T, ok bool) {
+	if h.n == nil || h.n.removed {
+		return
+	}
+	return h.n.value, true
+}
+
+// RemoveHandle removes the element identified by h from the OrderedSet, returning true if it
+// was still present. It is safe to call RemoveHandle more than once on the same Handle; later
+// calls return false.
+func (o *OrderedSet[T]) RemoveHandle(h Handle[T]) bool {
+	v, ok := o.ValueOf(h)
+	if !ok {
+		return false
+	}
+	return o.Remove(v)
+}
+
+// MoveHandle repositions the element identified by h relative to the element identified by
+// mark, returning false if either Handle no longer refers to an element in the OrderedSet. If
+// after is true, h is moved to immediately behind mark, otherwise immediately in front of it.
+func (o *OrderedSet[T]) MoveHandle(h, mark Handle[T], after bool) bool {
+	v, ok := o.ValueOf(h)
+	if !ok {
+		return false
+	}
+	markV, ok := o.ValueOf(mark)
+	if !ok {
+		return false
+	}
+	return o.Move(v, markV, after) == nil
+}
@@ -0,0 +1,116 @@
+package ordset_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kklash/ordset"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("AppendH/PrependH/ValueOf", func(t *testing.T) {
+		set := ordset.New[int]()
+
+		h1 := set.AppendH(1)
+		h2 := set.PrependH(0)
+
+		if v, ok := set.ValueOf(h1); !ok || v != 1 {
+			t.Errorf("expected ValueOf(h1) to return (1, true), got (%d, %v)", v, ok)
+			return
+		}
+		if v, ok := set.ValueOf(h2); !ok || v != 0 {
+			t.Errorf("expected ValueOf(h2) to return (0, true), got (%d, %v)", v, ok)
+			return
+		}
+		if !reflect.DeepEqual(set.Slice(), []int{0, 1}) {
+			t.Errorf("unexpected slice: %v", set.Slice())
+			return
+		}
+
+		// re-appending the same value returns the existing element's handle
+		h1Again := set.AppendH(1)
+		if h1Again != h1 {
+			t.Errorf("expected AppendH of existing member to return the same handle")
+			return
+		}
+	})
+
+	t.Run("handles track elements mutated through pointer identity", func(t *testing.T) {
+		type box struct{ n int }
+
+		set := ordset.New[*box]()
+		b := &box{n: 1}
+		h := set.AppendH(b)
+
+		b.n = 2 // mutate in place; the *box identity used as the map key is unchanged
+
+		v, ok := set.ValueOf(h)
+		if !ok || v != b {
+			t.Errorf("expected ValueOf to still resolve the handle after mutation")
+			return
+		}
+		if v.n != 2 {
+			t.Errorf("expected mutation to be visible through the resolved value")
+			return
+		}
+	})
+
+	t.Run("RemoveHandle", func(t *testing.T) {
+		set := ordset.New[int](1, 2, 3)
+		h := set.AppendH(2)
+
+		if !set.RemoveHandle(h) {
+			t.Errorf("expected RemoveHandle to return true")
+			return
+		}
+		if set.Has(2) {
+			t.Errorf("expected 2 to be removed from the set")
+			return
+		}
+		if set.RemoveHandle(h) {
+			t.Errorf("expected second RemoveHandle on the same handle to return false")
+			return
+		}
+		if _, ok := set.ValueOf(h); ok {
+			t.Errorf("expected ValueOf to fail for a removed handle")
+			return
+		}
+	})
+
+	t.Run("MoveHandle", func(t *testing.T) {
+		set := ordset.New[int](1, 2, 4, 3, 5)
+		h4 := set.AppendH(4)
+		h3 := set.AppendH(3)
+
+		if !set.MoveHandle(h4, h3, true) {
+			t.Errorf("expected MoveHandle to return true")
+			return
+		}
+		if !reflect.DeepEqual(set.Slice(), []int{1, 2, 3, 4, 5}) {
+			t.Errorf("unexpected slice after MoveHandle: %v", set.Slice())
+			return
+		}
+
+		// h4 must still resolve, and still be usable, after the element it identifies moved
+		if v, ok := set.ValueOf(h4); !ok || v != 4 {
+			t.Errorf("expected h4 to still resolve to 4 after being moved, got (%d, %v)", v, ok)
+			return
+		}
+
+		h2 := set.AppendH(2)
+		if !set.MoveHandle(h4, h2, false) {
+			t.Errorf("expected a second MoveHandle using h4 to succeed")
+			return
+		}
+		if !reflect.DeepEqual(set.Slice(), []int{1, 4, 2, 3, 5}) {
+			t.Errorf("unexpected slice after second MoveHandle: %v", set.Slice())
+			return
+		}
+
+		set.RemoveHandle(h3)
+		if set.MoveHandle(h4, h3, true) {
+			t.Errorf("expected MoveHandle with a removed mark to return false")
+			return
+		}
+	})
+}
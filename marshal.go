@@ -0,0 +1,70 @@
+package ordset
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON encodes the OrderedSet as a JSON array, preserving insertion order.
+func (o *OrderedSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.Slice())
+}
+
+// UnmarshalJSON decodes a JSON array into the OrderedSet, discarding o's previous contents.
+// Elements are added via Append, so duplicate values in the array are collapsed to one.
+func (o *OrderedSet[T]) UnmarshalJSON(data []byte) error {
+	var elems []T
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	*o = *New[T](elems...)
+	return nil
+}
+
+// GobEncode encodes the OrderedSet for use with the encoding/gob package, preserving insertion
+// order.
+func (o *OrderedSet[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(o.Slice()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes data produced by GobEncode into the OrderedSet, discarding o's previous
+// contents. Elements are added via Append, so duplicate values in the encoded data are
+// collapsed to one.
+func (o *OrderedSet[T]) GobDecode(data []byte) error {
+	var elems []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&elems); err != nil {
+		return err
+	}
+	*o = *New[T](elems...)
+	return nil
+}
+
+// MarshalText encodes the OrderedSet as a comma-separated list of its elements' own text
+// encodings, provided T implements encoding.TextMarshaler. This lets an OrderedSet be used
+// anywhere a single text value is expected, such as an environment variable or a flag default.
+func (o *OrderedSet[T]) MarshalText() ([]byte, error) {
+	parts := make([][]byte, 0, o.Len())
+	err := o.Range(func(_ int, v T) error {
+		marshaler, ok := any(v).(encoding.TextMarshaler)
+		if !ok {
+			return fmt.Errorf("ordset: element type %T does not implement encoding.TextMarshaler", v)
+		}
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return err
+		}
+		parts = append(parts, text)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Join(parts, []byte(",")), nil
+}
@@ -0,0 +1,89 @@
+package ordset_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kklash/ordset"
+)
+
+// word implements encoding.TextMarshaler/TextUnmarshaler for use in MarshalText tests.
+type word string
+
+func (w word) MarshalText() ([]byte, error) {
+	return []byte(w), nil
+}
+
+func (w *word) UnmarshalText(text []byte) error {
+	*w = word(text)
+	return nil
+}
+
+func TestMarshaling(t *testing.T) {
+	t.Run("JSON round trip", func(t *testing.T) {
+		set := ordset.New[int](3, 1, 4, 1, 5)
+
+		data, err := json.Marshal(set)
+		if err != nil {
+			t.Errorf("failed to marshal set: %s", err)
+			return
+		}
+		if string(data) != "[3,1,4,5]" {
+			t.Errorf("unexpected JSON encoding: %s", data)
+			return
+		}
+
+		var decoded ordset.OrderedSet[int]
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Errorf("failed to unmarshal set: %s", err)
+			return
+		}
+		if !reflect.DeepEqual(decoded.Slice(), set.Slice()) {
+			t.Errorf("decoded set does not match original: %v != %v", decoded.Slice(), set.Slice())
+			return
+		}
+	})
+
+	t.Run("gob round trip", func(t *testing.T) {
+		set := ordset.New[string]("a", "b", "c")
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(set); err != nil {
+			t.Errorf("failed to gob-encode set: %s", err)
+			return
+		}
+
+		var decoded ordset.OrderedSet[string]
+		if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+			t.Errorf("failed to gob-decode set: %s", err)
+			return
+		}
+		if !reflect.DeepEqual(decoded.Slice(), set.Slice()) {
+			t.Errorf("decoded set does not match original: %v != %v", decoded.Slice(), set.Slice())
+			return
+		}
+	})
+
+	t.Run("MarshalText", func(t *testing.T) {
+		set := ordset.New[word]("foo", "bar")
+
+		text, err := set.MarshalText()
+		if err != nil {
+			t.Errorf("failed to marshal text: %s", err)
+			return
+		}
+		if string(text) != "foo,bar" {
+			t.Errorf("unexpected text encoding: %s", text)
+			return
+		}
+
+		unsupported := ordset.New[int](1, 2)
+		if _, err := unsupported.MarshalText(); err == nil {
+			t.Errorf("expected error marshaling text for a type without encoding.TextMarshaler")
+			return
+		}
+	})
+}
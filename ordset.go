@@ -1,10 +1,13 @@
 // Package ordset implements an ordered set data structure using generics.
 //
-// An OrderedSet is a combination of a linked list and a hash map. As values are appended/prepended to the linked list,
-// their list element pointers are stored in a hash map. This way, set membership checks (is element 'x' in the set?)
-// can be done in constant time. Comparatively equal (==) elements are not duplicated when they are added to the set.
+// An OrderedSet is backed by an order-statistic tree (a treap augmented with subtree sizes) and
+// a hash map from elements to their tree node. The map gives O(1) membership checks (is element
+// 'x' in the set?), while the tree gives O(log n) positional access and insertion anywhere in
+// the sequence. Comparatively equal (==) elements are not duplicated when they are added to the
+// set.
 //
-// Think of an OrderedSet as queue of its own keys, where each key can be quickly looked up to find its position in the queue.
+// Think of an OrderedSet as queue of its own keys, where each key can be quickly looked up to
+// find its position in the queue.
 //
 // Example usage of an ordered set:
 //
@@ -23,7 +26,7 @@
 //  set.Slice() // []int{0, 1, 2}
 //
 //
-// Elements in the set can be removed in constant-time while preserving the order of the queue.
+// Elements in the set can be removed in logarithmic time while preserving the order of the queue.
 //
 //  set.Slice() // []int{0, 10, 20}
 //  set.Remove(10)
@@ -36,28 +39,151 @@
 //    fmt.Println(i, str) // prints "0 zero", "1 one", etc
 //    return nil
 //  })
+//
+// Elements can also be looked up by their position, or a position looked up by value.
+//
+//  set.Slice()         // []string{"zero", "one", "two", "three"}
+//  set.At(2)           // "two", true
+//  set.IndexOf("three") // 3, true
 package ordset
 
 import (
-	"container/list"
 	"errors"
+	"math/rand"
 )
 
 var ErrMarkNotFound = errors.New("reference value for insert is not in the OrderedSet")
 
+// node is one node of the treap backing an OrderedSet. Each node is augmented with the size of
+// its subtree, so the tree doubles as an order-statistic tree: descending by comparing a target
+// index against left-subtree sizes locates the element at that position, and walking up from a
+// node to the root by summing left-subtree sizes recovers its position.
+type node[T comparable] struct {
+	value       T
+	left, right *node[T]
+	parent      *node[T]
+	priority    int32
+	size        int
+
+	// removed marks a node that has been taken out of the tree and dropped from mapping, so
+	// that a Handle (see handle.go) still referencing it can tell it's stale in O(1) without
+	// re-hashing its value. A node being repositioned within the tree, as Move does, is never
+	// marked removed.
+	removed bool
+}
+
+func nodeSize[T comparable](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// updateNode recomputes n's subtree size and re-links its children's parent pointers. It must
+// be called after n's left or right child is reassigned.
+func updateNode[T comparable](n *node[T]) {
+	if n == nil {
+		return
+	}
+	n.size = 1 + nodeSize(n.left) + nodeSize(n.right)
+	if n.left != nil {
+		n.left.parent = n
+	}
+	if n.right != nil {
+		n.right.parent = n
+	}
+}
+
+// split divides the treap rooted at n into two treaps by position: the first k elements (in
+// left-to-right order) and the rest. Either half may come back nil if it would be empty.
+func split[T comparable](n *node[T], k int) (left, right *node[T]) {
+	if n == nil {
+		return nil, nil
+	}
+	leftSize := nodeSize(n.left)
+	if k <= leftSize {
+		l, r := split(n.left, k)
+		n.left = r
+		updateNode(n)
+		n.parent = nil
+		if l != nil {
+			l.parent = nil
+		}
+		return l, n
+	}
+	l, r := split(n.right, k-leftSize-1)
+	n.right = l
+	updateNode(n)
+	n.parent = nil
+	if r != nil {
+		r.parent = nil
+	}
+	return n, r
+}
+
+// merge joins two treaps, l entirely before r, into one, restoring the heap property on
+// priority.
+func merge[T comparable](l, r *node[T]) *node[T] {
+	if l == nil {
+		return r
+	}
+	if r == nil {
+		return l
+	}
+	if l.priority > r.priority {
+		l.right = merge(l.right, r)
+		updateNode(l)
+		l.parent = nil
+		return l
+	}
+	r.left = merge(l, r.left)
+	updateNode(r)
+	r.parent = nil
+	return r
+}
+
+// extractAt splits the node at position i out of the tree, returning the tree with it removed
+// and the extracted node itself — the very same *node[T], not a copy — detached from its
+// former neighbors. This lets callers reposition a node elsewhere in the tree, as Move does,
+// without disturbing the identity that a Handle (see handle.go) may reference.
+func extractAt[T comparable](root *node[T], i int) (remaining, extracted *node[T]) {
+	left, mid := split(root, i)
+	extracted, right := split(mid, 1)
+	return merge(left, right), extracted
+}
+
+// insertNode splices the already-detached node n into the tree at position i, without
+// allocating anything, so any Handle referencing n remains valid.
+func insertNode[T comparable](root *node[T], i int, n *node[T]) *node[T] {
+	left, right := split(root, i)
+	return merge(merge(left, n), right)
+}
+
+// indexOfNode walks from n up to the root, summing the sizes of left subtrees it passes, to
+// recover n's position in the sequence.
+func indexOfNode[T comparable](n *node[T]) int {
+	idx := nodeSize(n.left)
+	for n.parent != nil {
+		if n.parent.right == n {
+			idx += nodeSize(n.parent.left) + 1
+		}
+		n = n.parent
+	}
+	return idx
+}
+
 // OrderedSet is an implementation of an ordered set of elements of type T. Appending, prepending
-// or inserting into the set stores values in a linked list which encodes the order of the elements,
-// and in a hash map which allows for quick lookup of elements.
+// or inserting into the set stores values in an order-statistic tree which encodes the order of
+// the elements, and in a hash map which allows for quick lookup of elements.
 type OrderedSet[T comparable] struct {
-	list    *list.List
-	mapping map[T]*list.Element
+	root    *node[T]
+	mapping map[T]*node[T]
 }
 
 // New initializes an OrderedSet of element type T with an initial set of elements.
 func New[T comparable](elems ...T) *OrderedSet[T] {
 	set := &OrderedSet[T]{
-		list:    list.New(),
-		mapping: make(map[T]*list.Element),
+		mapping: make(map[T]*node[T]),
 	}
 	for _, v := range elems {
 		set.Append(v)
@@ -67,7 +193,7 @@ func New[T comparable](elems ...T) *OrderedSet[T] {
 
 // Len returns the number of elements in the OrderedSet.
 func (o *OrderedSet[T]) Len() int {
-	return o.list.Len()
+	return nodeSize(o.root)
 }
 
 // Has returns true if the given value v is a member of the OrderedSet.
@@ -76,56 +202,95 @@ func (o *OrderedSet[T]) Has(v T) bool {
 	return exists
 }
 
+// At returns the element at position i of the OrderedSet, and true, or the zero value and false
+// if i is out of range.
+func (o *OrderedSet[T]) At(i int) (v T, ok bool) {
+	if i < 0 || i >= o.Len() {
+		return
+	}
+	n := o.root
+	for {
+		leftSize := nodeSize(n.left)
+		switch {
+		case i < leftSize:
+			n = n.left
+		case i == leftSize:
+			return n.value, true
+		default:
+			i -= leftSize + 1
+			n = n.right
+		}
+	}
+}
+
+// IndexOf returns the position of v in the OrderedSet, and true, or 0 and false if v is not a
+// member.
+func (o *OrderedSet[T]) IndexOf(v T) (int, bool) {
+	n, ok := o.mapping[v]
+	if !ok {
+		return 0, false
+	}
+	return indexOfNode(n), true
+}
+
 // Front returns the element at the front of the OrderedSet.
 func (o *OrderedSet[T]) Front() T {
-	return o.list.Front().Value.(T)
+	v, _ := o.At(0)
+	return v
 }
 
 // Back returns the element at the front of the OrderedSet.
 func (o *OrderedSet[T]) Back() T {
-	return o.list.Back().Value.(T)
+	v, _ := o.At(o.Len() - 1)
+	return v
 }
 
-// Append pushes a value to the back of the OrderedSet.
-func (o *OrderedSet[T]) Append(v T) bool {
-	if o.Has(v) {
+// InsertAt inserts v at position i of the OrderedSet, shifting later elements back. It returns
+// false without modifying the set if v is already a member, or if i is out of range.
+func (o *OrderedSet[T]) InsertAt(i int, v T) bool {
+	if o.Has(v) || i < 0 || i > o.Len() {
 		return false
 	}
-	o.mapping[v] = o.list.PushBack(v)
+	newNode := &node[T]{value: v, priority: rand.Int31(), size: 1}
+	o.root = insertNode(o.root, i, newNode)
+	o.mapping[v] = newNode
 	return true
 }
 
+// RemoveAt removes and returns the element at position i of the OrderedSet, and true, or the
+// zero value and false if i is out of range.
+func (o *OrderedSet[T]) RemoveAt(i int) (v T, ok bool) {
+	if i < 0 || i >= o.Len() {
+		return
+	}
+	remaining, extracted := extractAt(o.root, i)
+	v = extracted.value
+	extracted.removed = true
+	delete(o.mapping, v)
+	o.root = remaining
+	return v, true
+}
+
+// Append pushes a value to the back of the OrderedSet.
+func (o *OrderedSet[T]) Append(v T) bool {
+	return o.InsertAt(o.Len(), v)
+}
+
 // Prepend pushes a value to the front of the OrderedSet.
 func (o *OrderedSet[T]) Prepend(v T) bool {
-	if o.Has(v) {
-		return false
-	}
-	o.mapping[v] = o.list.PushFront(v)
-	return true
+	return o.InsertAt(0, v)
 }
 
 // Pop extracts and removes a value from the right of the OrderedSet. Returns a boolean true value
 // if an element was successfully popped. This will only ever be false if the OrderedSet is empty.
 func (o *OrderedSet[T]) Pop() (v T, ok bool) {
-	if o.Len() > 0 {
-		elem := o.list.Back()
-		o.list.Remove(elem)
-		v, ok = elem.Value.(T)
-		delete(o.mapping, v)
-	}
-	return
+	return o.RemoveAt(o.Len() - 1)
 }
 
 // Shift extracts and removes a value from the left of the OrderedSet. Returns a boolean true value
 // if an element was successfully popped. This will only ever be false if the OrderedSet is empty.
 func (o *OrderedSet[T]) Shift() (v T, ok bool) {
-	if o.Len() > 0 {
-		elem := o.list.Front()
-		o.list.Remove(elem)
-		v, ok = elem.Value.(T)
-		delete(o.mapping, v)
-	}
-	return
+	return o.RemoveAt(0)
 }
 
 // Insert inserts the given value v into the OrderedSet at a specific position relative to the given mark value.
@@ -134,7 +299,8 @@ func (o *OrderedSet[T]) Shift() (v T, ok bool) {
 //
 // If the value v is already a member of the set, Insert is a no-op. Use the Move method to reorder set elements.
 func (o *OrderedSet[T]) Insert(v, mark T, after bool) (added bool, err error) {
-	if !o.Has(mark) {
+	markIdx, ok := o.IndexOf(mark)
+	if !ok {
 		return false, ErrMarkNotFound
 	} else if o.Has(v) {
 		// value already exists in set, no-op
@@ -142,61 +308,87 @@ func (o *OrderedSet[T]) Insert(v, mark T, after bool) (added bool, err error) {
 	}
 
 	if after {
-		o.mapping[v] = o.list.InsertAfter(v, o.mapping[mark])
-	} else {
-		o.mapping[v] = o.list.InsertBefore(v, o.mapping[mark])
+		markIdx++
 	}
+	o.InsertAt(markIdx, v)
 	return true, nil
 }
 
 // Move reorders repositions the set element value v relative to the given mark value.
 // If the after parameter is true, the value v is moved to immediately behind mark. If
 // after is false, v is moved to immediately in front of mark.
+//
+// The element's underlying node is repositioned in place rather than removed and recreated, so
+// any Handle obtained for v (see AppendH/PrependH) remains valid after the move.
 func (o *OrderedSet[T]) Move(v, mark T, after bool) (err error) {
-	if !o.Has(mark) {
+	markIdx, ok := o.IndexOf(mark)
+	if !ok {
 		return ErrMarkNotFound
 	}
-	if elem, ok := o.mapping[v]; ok {
-		if after {
-			o.list.MoveAfter(elem, o.mapping[mark])
-		} else {
-			o.list.MoveBefore(elem, o.mapping[mark])
-		}
+	if v == mark {
+		return nil
+	}
+	vIdx, ok := o.IndexOf(v)
+	if !ok {
+		return nil
+	}
+
+	remaining, n := extractAt(o.root, vIdx)
+	if vIdx < markIdx {
+		markIdx--
+	}
+	if after {
+		markIdx++
 	}
+	o.root = insertNode(remaining, markIdx, n)
 	return nil
 }
 
 func (o *OrderedSet[T]) Remove(v T) bool {
-	if elem, ok := o.mapping[v]; ok {
-		o.list.Remove(elem)
-		delete(o.mapping, v)
-		return true
+	idx, ok := o.IndexOf(v)
+	if !ok {
+		return false
 	}
-	return false
+	_, ok = o.RemoveAt(idx)
+	return ok
 }
 
 func (o *OrderedSet[T]) Range(loop func(int, T) error) error {
 	i := 0
-	for elem := o.list.Front(); elem != nil; elem = elem.Next() {
-		err := loop(i, elem.Value.(T))
-		if err != nil {
+	var visit func(n *node[T]) error
+	visit = func(n *node[T]) error {
+		if n == nil {
+			return nil
+		}
+		if err := visit(n.left); err != nil {
+			return err
+		}
+		if err := loop(i, n.value); err != nil {
 			return err
 		}
 		i++
+		return visit(n.right)
 	}
-	return nil
+	return visit(o.root)
 }
 
 func (o *OrderedSet[T]) RangeReverse(loop func(int, T) error) error {
 	i := 0
-	for elem := o.list.Back(); elem != nil; elem = elem.Prev() {
-		err := loop(i, elem.Value.(T))
-		if err != nil {
+	var visit func(n *node[T]) error
+	visit = func(n *node[T]) error {
+		if n == nil {
+			return nil
+		}
+		if err := visit(n.right); err != nil {
+			return err
+		}
+		if err := loop(i, n.value); err != nil {
 			return err
 		}
 		i++
+		return visit(n.left)
 	}
-	return nil
+	return visit(o.root)
 }
 
 func (o *OrderedSet[T]) Slice() []T {
@@ -0,0 +1,325 @@
+package ordset
+
+import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+)
+
+const (
+	trieBits     = 5
+	trieMask     = 1<<trieBits - 1
+	maxTrieDepth = 7 // 7 * trieBits >= 32 bits of hash
+)
+
+// persistentListNode is an immutable node in a singly linked list tracking the insertion order
+// of a PersistentOrderedSet. Nodes are never mutated once created, so two versions of a set can
+// safely share any suffix of their list.
+type persistentListNode[T comparable] struct {
+	value T
+	next  *persistentListNode[T]
+}
+
+// persistentTrieNode is one level of a 32-way hash trie used to test membership in a
+// PersistentOrderedSet. Only the path from the root to a changed leaf is ever copied when the
+// trie is updated (path copying), so sibling branches are shared between versions.
+type persistentTrieNode[T comparable] struct {
+	values   []T
+	children [1 << trieBits]*persistentTrieNode[T]
+}
+
+// hashOf computes a hash for an arbitrary comparable value. Go generics offer no Hash constraint
+// for comparable types, so the value is hashed via its default formatting, which is stable for
+// any T whose == operator ordset already relies on elsewhere. Pointers compare by address, so a
+// pointer is hashed by its address rather than by the value it points to, which may change.
+func hashOf[T comparable](v T) uint32 {
+	h := fnv.New32a()
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer {
+		fmt.Fprintf(h, "%#x", rv.Pointer())
+	} else {
+		fmt.Fprintf(h, "%#v", v)
+	}
+	return h.Sum32()
+}
+
+func trieHas[T comparable](node *persistentTrieNode[T], v T, hash uint32, depth int) bool {
+	if node == nil {
+		return false
+	}
+	if depth == maxTrieDepth {
+		for _, existing := range node.values {
+			if existing == v {
+				return true
+			}
+		}
+		return false
+	}
+	idx := (hash >> (uint(depth) * trieBits)) & trieMask
+	return trieHas(node.children[idx], v, hash, depth+1)
+}
+
+func trieInsert[T comparable](node *persistentTrieNode[T], v T, hash uint32, depth int) *persistentTrieNode[T] {
+	var clone persistentTrieNode[T]
+	if node != nil {
+		clone = *node
+	}
+	if depth == maxTrieDepth {
+		clone.values = append(append([]T{}, clone.values...), v)
+		return &clone
+	}
+	idx := (hash >> (uint(depth) * trieBits)) & trieMask
+	clone.children[idx] = trieInsert(clone.children[idx], v, hash, depth+1)
+	return &clone
+}
+
+func trieRemove[T comparable](node *persistentTrieNode[T], v T, hash uint32, depth int) *persistentTrieNode[T] {
+	if node == nil {
+		return nil
+	}
+	clone := *node
+	if depth == maxTrieDepth {
+		filtered := make([]T, 0, len(clone.values))
+		for _, existing := range clone.values {
+			if existing != v {
+				filtered = append(filtered, existing)
+			}
+		}
+		clone.values = filtered
+		return &clone
+	}
+	idx := (hash >> (uint(depth) * trieBits)) & trieMask
+	clone.children[idx] = trieRemove(clone.children[idx], v, hash, depth+1)
+	return &clone
+}
+
+func listPrepend[T comparable](head *persistentListNode[T], v T) *persistentListNode[T] {
+	return &persistentListNode[T]{value: v, next: head}
+}
+
+func listAppend[T comparable](head *persistentListNode[T], v T) *persistentListNode[T] {
+	if head == nil {
+		return &persistentListNode[T]{value: v}
+	}
+	return &persistentListNode[T]{value: head.value, next: listAppend(head.next, v)}
+}
+
+func listRemove[T comparable](head *persistentListNode[T], v T) *persistentListNode[T] {
+	if head == nil {
+		return nil
+	}
+	if head.value == v {
+		return head.next
+	}
+	return &persistentListNode[T]{value: head.value, next: listRemove(head.next, v)}
+}
+
+// listInsert splices v immediately before or after mark, returning the new head and true. If
+// mark is not found, it returns the original head unchanged and false.
+func listInsert[T comparable](head *persistentListNode[T], v, mark T, after bool) (*persistentListNode[T], bool) {
+	if head == nil {
+		return head, false
+	}
+	if head.value == mark {
+		if after {
+			return &persistentListNode[T]{value: head.value, next: &persistentListNode[T]{value: v, next: head.next}}, true
+		}
+		return &persistentListNode[T]{value: v, next: head}, true
+	}
+	rest, ok := listInsert(head.next, v, mark, after)
+	if !ok {
+		return head, false
+	}
+	return &persistentListNode[T]{value: head.value, next: rest}, true
+}
+
+func listToSlice[T comparable](head *persistentListNode[T]) []T {
+	var out []T
+	for n := head; n != nil; n = n.next {
+		out = append(out, n.value)
+	}
+	return out
+}
+
+// PersistentOrderedSet is an immutable counterpart to OrderedSet. Every method which would
+// mutate an OrderedSet instead returns a new *PersistentOrderedSet, leaving the receiver and
+// all of its existing values completely untouched. This makes it safe to keep earlier versions
+// around as undo history, or to hand them to concurrent readers without locking.
+//
+// Membership is tracked with a persistent hash trie, so Has, Append and Remove only copy the
+// handful of trie nodes along the path they touch rather than the whole set. Order, however, is
+// tracked with a persistent singly linked list: Prepend shares the entire existing list in O(1),
+// but Append, Remove, Insert and Move all have to rebuild every list node from the head up to
+// the affected position, which is O(n) for Append in particular, since the affected position is
+// always the tail. That makes NewPersistent(elems...) O(n²) overall. Clone itself is still O(1):
+// since nothing is ever mutated in place, it just needs to copy the two root pointers below.
+type PersistentOrderedSet[T comparable] struct {
+	order *persistentListNode[T]
+	trie  *persistentTrieNode[T]
+	len   int
+}
+
+// NewPersistent initializes a PersistentOrderedSet of element type T with an initial set of
+// elements, added in order as if by Append.
+func NewPersistent[T comparable](elems ...T) *PersistentOrderedSet[T] {
+	p := &PersistentOrderedSet[T]{}
+	for _, v := range elems {
+		p = p.Append(v)
+	}
+	return p
+}
+
+// Clone returns a PersistentOrderedSet with its own identity that shares all of p's underlying
+// data. Because that data is never mutated in place, this is all Clone ever needs to do.
+func (p *PersistentOrderedSet[T]) Clone() *PersistentOrderedSet[T] {
+	clone := *p
+	return &clone
+}
+
+// Len returns the number of elements in the PersistentOrderedSet.
+func (p *PersistentOrderedSet[T]) Len() int {
+	return p.len
+}
+
+// Has returns true if the given value v is a member of the PersistentOrderedSet.
+func (p *PersistentOrderedSet[T]) Has(v T) bool {
+	return trieHas(p.trie, v, hashOf(v), 0)
+}
+
+// Front returns the element at the front of the PersistentOrderedSet, or the zero value if it
+// is empty.
+func (p *PersistentOrderedSet[T]) Front() T {
+	if p.order == nil {
+		var zero T
+		return zero
+	}
+	return p.order.value
+}
+
+// Back returns the element at the back of the PersistentOrderedSet, or the zero value if it is
+// empty.
+func (p *PersistentOrderedSet[T]) Back() T {
+	if p.order == nil {
+		var zero T
+		return zero
+	}
+	n := p.order
+	for n.next != nil {
+		n = n.next
+	}
+	return n.value
+}
+
+// Append returns a new PersistentOrderedSet with v added to the back. If v is already a member,
+// Append returns p unchanged.
+func (p *PersistentOrderedSet[T]) Append(v T) *PersistentOrderedSet[T] {
+	if p.Has(v) {
+		return p
+	}
+	return &PersistentOrderedSet[T]{
+		order: listAppend(p.order, v),
+		trie:  trieInsert(p.trie, v, hashOf(v), 0),
+		len:   p.len + 1,
+	}
+}
+
+// Prepend returns a new PersistentOrderedSet with v added to the front. If v is already a
+// member, Prepend returns p unchanged.
+func (p *PersistentOrderedSet[T]) Prepend(v T) *PersistentOrderedSet[T] {
+	if p.Has(v) {
+		return p
+	}
+	return &PersistentOrderedSet[T]{
+		order: listPrepend(p.order, v),
+		trie:  trieInsert(p.trie, v, hashOf(v), 0),
+		len:   p.len + 1,
+	}
+}
+
+// Remove returns a new PersistentOrderedSet with v removed, if present. If v is not a member,
+// Remove returns p unchanged.
+func (p *PersistentOrderedSet[T]) Remove(v T) *PersistentOrderedSet[T] {
+	if !p.Has(v) {
+		return p
+	}
+	return &PersistentOrderedSet[T]{
+		order: listRemove(p.order, v),
+		trie:  trieRemove(p.trie, v, hashOf(v), 0),
+		len:   p.len - 1,
+	}
+}
+
+// Insert returns a new PersistentOrderedSet with v inserted at a specific position relative to
+// the given mark value. If after is true, v is inserted immediately behind mark, otherwise
+// immediately in front of it.
+//
+// If v is already a member of the set, Insert returns p unchanged. Use Move to reorder elements.
+func (p *PersistentOrderedSet[T]) Insert(v, mark T, after bool) (*PersistentOrderedSet[T], error) {
+	if !p.Has(mark) {
+		return p, ErrMarkNotFound
+	} else if p.Has(v) {
+		return p, nil
+	}
+
+	order, ok := listInsert(p.order, v, mark, after)
+	if !ok {
+		return p, ErrMarkNotFound
+	}
+	return &PersistentOrderedSet[T]{
+		order: order,
+		trie:  trieInsert(p.trie, v, hashOf(v), 0),
+		len:   p.len + 1,
+	}, nil
+}
+
+// Move returns a new PersistentOrderedSet with v repositioned relative to the given mark value.
+// If after is true, v is moved to immediately behind mark, otherwise immediately in front of it.
+func (p *PersistentOrderedSet[T]) Move(v, mark T, after bool) (*PersistentOrderedSet[T], error) {
+	if !p.Has(mark) {
+		return p, ErrMarkNotFound
+	} else if v == mark || !p.Has(v) {
+		return p, nil
+	}
+
+	order, ok := listInsert(listRemove(p.order, v), v, mark, after)
+	if !ok {
+		return p, ErrMarkNotFound
+	}
+	return &PersistentOrderedSet[T]{
+		order: order,
+		trie:  p.trie,
+		len:   p.len,
+	}, nil
+}
+
+// Range calls loop once for every element of the PersistentOrderedSet, front to back, passing
+// its index and value. Range stops and returns the first non-nil error loop returns.
+func (p *PersistentOrderedSet[T]) Range(loop func(int, T) error) error {
+	i := 0
+	for n := p.order; n != nil; n = n.next {
+		if err := loop(i, n.value); err != nil {
+			return err
+		}
+		i++
+	}
+	return nil
+}
+
+// RangeReverse calls loop once for every element of the PersistentOrderedSet, back to front,
+// passing its index and value. RangeReverse stops and returns the first non-nil error loop
+// returns.
+func (p *PersistentOrderedSet[T]) RangeReverse(loop func(int, T) error) error {
+	values := listToSlice(p.order)
+	i := 0
+	for j := len(values) - 1; j >= 0; j-- {
+		if err := loop(i, values[j]); err != nil {
+			return err
+		}
+		i++
+	}
+	return nil
+}
+
+// Slice returns the elements of the PersistentOrderedSet as a slice, front to back.
+func (p *PersistentOrderedSet[T]) Slice() []T {
+	return listToSlice(p.order)
+}
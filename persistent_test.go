@@ -0,0 +1,118 @@
+package ordset_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kklash/ordset"
+)
+
+func TestPersistentOrderedSet(t *testing.T) {
+	t.Run("Append/Prepend/Has/Len leave earlier versions untouched", func(t *testing.T) {
+		v0 := ordset.NewPersistent[int]()
+		v1 := v0.Append(1)
+		v2 := v1.Append(2)
+		v3 := v2.Prepend(0)
+
+		if v0.Len() != 0 || v1.Len() != 1 || v2.Len() != 2 || v3.Len() != 3 {
+			t.Errorf("unexpected lengths: %d %d %d %d", v0.Len(), v1.Len(), v2.Len(), v3.Len())
+			return
+		}
+		if v1.Has(2) || v2.Has(0) {
+			t.Errorf("earlier versions should not see values added later")
+			return
+		}
+		if !reflect.DeepEqual(v3.Slice(), []int{0, 1, 2}) {
+			t.Errorf("unexpected slice for v3: %v", v3.Slice())
+			return
+		}
+
+		// re-appending a member is a no-op which returns the same version
+		if v3.Append(1) != v3 {
+			t.Errorf("expected Append of existing member to return the same version")
+			return
+		}
+	})
+
+	t.Run("Front/Back on an empty set return the zero value", func(t *testing.T) {
+		empty := ordset.NewPersistent[int]()
+		if v := empty.Front(); v != 0 {
+			t.Errorf("expected Front of empty set to return 0, got %d", v)
+			return
+		}
+		if v := empty.Back(); v != 0 {
+			t.Errorf("expected Back of empty set to return 0, got %d", v)
+			return
+		}
+	})
+
+	t.Run("appending to a Clone leaves the original untouched", func(t *testing.T) {
+		original := ordset.NewPersistent[int](1, 2, 3)
+		clone := original.Clone()
+
+		modified := clone.Append(4)
+		if original.Has(4) {
+			t.Errorf("expected original to be unaffected by mutation of its clone")
+			return
+		}
+		if !modified.Has(4) || !reflect.DeepEqual(modified.Slice(), []int{1, 2, 3, 4}) {
+			t.Errorf("unexpected result after appending to clone: %v", modified.Slice())
+			return
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		v1 := ordset.NewPersistent[int](1, 2, 3)
+		v2 := v1.Remove(2)
+
+		if !reflect.DeepEqual(v1.Slice(), []int{1, 2, 3}) {
+			t.Errorf("expected v1 to be unaffected by Remove on v2: %v", v1.Slice())
+			return
+		}
+		if !reflect.DeepEqual(v2.Slice(), []int{1, 3}) {
+			t.Errorf("unexpected slice after remove: %v", v2.Slice())
+			return
+		}
+	})
+
+	t.Run("Insert", func(t *testing.T) {
+		v1 := ordset.NewPersistent[int](1, 2, 3, 5)
+		v2, err := v1.Insert(4, 3, true)
+		if err != nil {
+			t.Errorf("failed to insert value 4: %s", err)
+			return
+		}
+
+		if !reflect.DeepEqual(v2.Slice(), []int{1, 2, 3, 4, 5}) {
+			t.Errorf("unexpected slice after insert: %v", v2.Slice())
+			return
+		}
+		if !reflect.DeepEqual(v1.Slice(), []int{1, 2, 3, 5}) {
+			t.Errorf("expected v1 to be unaffected by insert on v2: %v", v1.Slice())
+			return
+		}
+
+		if _, err := v1.Insert(4, 99999, true); err != ordset.ErrMarkNotFound {
+			t.Errorf("expected ErrMarkNotFound, got %v", err)
+			return
+		}
+	})
+
+	t.Run("Move", func(t *testing.T) {
+		v1 := ordset.NewPersistent[int](1, 2, 4, 3, 5)
+		v2, err := v1.Move(4, 3, true)
+		if err != nil {
+			t.Errorf("failed to move value: %s", err)
+			return
+		}
+
+		if !reflect.DeepEqual(v2.Slice(), []int{1, 2, 3, 4, 5}) {
+			t.Errorf("unexpected slice after move: %v", v2.Slice())
+			return
+		}
+		if !reflect.DeepEqual(v1.Slice(), []int{1, 2, 4, 3, 5}) {
+			t.Errorf("expected v1 to be unaffected by move on v2: %v", v1.Slice())
+			return
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package ordset_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kklash/ordset"
+)
+
+func TestPositionalAccess(t *testing.T) {
+	t.Run("At", func(t *testing.T) {
+		set := ordset.New[int](10, 20, 30, 40)
+
+		for i, expected := range []int{10, 20, 30, 40} {
+			if v, ok := set.At(i); !ok || v != expected {
+				t.Errorf("expected At(%d) to return (%d, true), got (%d, %v)", i, expected, v, ok)
+				return
+			}
+		}
+		if _, ok := set.At(-1); ok {
+			t.Errorf("expected At(-1) to return ok=false")
+			return
+		}
+		if _, ok := set.At(4); ok {
+			t.Errorf("expected At(4) to return ok=false")
+			return
+		}
+	})
+
+	t.Run("IndexOf", func(t *testing.T) {
+		set := ordset.New[int](10, 20, 30, 40)
+
+		for expected, v := range []int{10, 20, 30, 40} {
+			if i, ok := set.IndexOf(v); !ok || i != expected {
+				t.Errorf("expected IndexOf(%d) to return (%d, true), got (%d, %v)", v, expected, i, ok)
+				return
+			}
+		}
+		if _, ok := set.IndexOf(99); ok {
+			t.Errorf("expected IndexOf(99) to return ok=false")
+			return
+		}
+	})
+
+	t.Run("InsertAt", func(t *testing.T) {
+		set := ordset.New[int](1, 2, 4)
+
+		if !set.InsertAt(2, 3) {
+			t.Errorf("expected InsertAt to return true")
+			return
+		}
+		if !reflect.DeepEqual(set.Slice(), []int{1, 2, 3, 4}) {
+			t.Errorf("unexpected slice after InsertAt: %v", set.Slice())
+			return
+		}
+
+		if set.InsertAt(0, 3) {
+			t.Errorf("expected InsertAt of an existing member to return false")
+			return
+		}
+		if set.InsertAt(99, 5) {
+			t.Errorf("expected InsertAt with an out-of-range index to return false")
+			return
+		}
+	})
+
+	t.Run("RemoveAt", func(t *testing.T) {
+		set := ordset.New[int](1, 2, 3, 4)
+
+		v, ok := set.RemoveAt(1)
+		if !ok || v != 2 {
+			t.Errorf("expected RemoveAt(1) to return (2, true), got (%d, %v)", v, ok)
+			return
+		}
+		if !reflect.DeepEqual(set.Slice(), []int{1, 3, 4}) {
+			t.Errorf("unexpected slice after RemoveAt: %v", set.Slice())
+			return
+		}
+		if set.Has(2) {
+			t.Errorf("expected 2 to no longer be a member")
+			return
+		}
+
+		if _, ok := set.RemoveAt(99); ok {
+			t.Errorf("expected RemoveAt with an out-of-range index to return ok=false")
+			return
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package ordset
+
+import "errors"
+
+// errStopRange is used internally to halt a Range call early once a predicate has been decided,
+// without leaking a meaningless error value out to callers.
+var errStopRange = errors.New("stop range")
+
+// Union returns a new OrderedSet containing every element of o followed by every element of other
+// which is not already present. The receiver's elements keep their relative order, and elements
+// contributed by other are appended in their own relative order.
+func (o *OrderedSet[T]) Union(other *OrderedSet[T]) *OrderedSet[T] {
+	result := New[T]()
+	o.Range(func(_ int, v T) error {
+		result.Append(v)
+		return nil
+	})
+	other.Range(func(_ int, v T) error {
+		result.Append(v)
+		return nil
+	})
+	return result
+}
+
+// Intersection returns a new OrderedSet containing only the elements present in both o and other,
+// in the order they appear in o.
+func (o *OrderedSet[T]) Intersection(other *OrderedSet[T]) *OrderedSet[T] {
+	result := New[T]()
+	o.Range(func(_ int, v T) error {
+		if other.Has(v) {
+			result.Append(v)
+		}
+		return nil
+	})
+	return result
+}
+
+// Difference returns a new OrderedSet containing the elements of o which are not present in other,
+// in the order they appear in o.
+func (o *OrderedSet[T]) Difference(other *OrderedSet[T]) *OrderedSet[T] {
+	result := New[T]()
+	o.Range(func(_ int, v T) error {
+		if !other.Has(v) {
+			result.Append(v)
+		}
+		return nil
+	})
+	return result
+}
+
+// SymmetricDifference returns a new OrderedSet containing the elements which belong to exactly one
+// of o or other: first the elements of o missing from other, then the elements of other missing from o.
+func (o *OrderedSet[T]) SymmetricDifference(other *OrderedSet[T]) *OrderedSet[T] {
+	result := o.Difference(other)
+	other.Range(func(_ int, v T) error {
+		if !o.Has(v) {
+			result.Append(v)
+		}
+		return nil
+	})
+	return result
+}
+
+// AddAll appends every element of other to o, in other's order. Elements already present in o are
+// left untouched.
+func (o *OrderedSet[T]) AddAll(other *OrderedSet[T]) {
+	other.Range(func(_ int, v T) error {
+		o.Append(v)
+		return nil
+	})
+}
+
+// RemoveAll removes every element of other from o, if present.
+func (o *OrderedSet[T]) RemoveAll(other *OrderedSet[T]) {
+	other.Range(func(_ int, v T) error {
+		o.Remove(v)
+		return nil
+	})
+}
+
+// RetainAll removes every element of o which is not also present in other, preserving the relative
+// order of the elements which remain.
+func (o *OrderedSet[T]) RetainAll(other *OrderedSet[T]) {
+	var toRemove []T
+	o.Range(func(_ int, v T) error {
+		if !other.Has(v) {
+			toRemove = append(toRemove, v)
+		}
+		return nil
+	})
+	for _, v := range toRemove {
+		o.Remove(v)
+	}
+}
+
+// Equal returns true if o and other contain exactly the same elements, regardless of order.
+func (o *OrderedSet[T]) Equal(other *OrderedSet[T]) bool {
+	return o.Len() == other.Len() && o.IsSubset(other)
+}
+
+// IsSubset returns true if every element of o is also a member of other.
+func (o *OrderedSet[T]) IsSubset(other *OrderedSet[T]) bool {
+	isSubset := true
+	o.Range(func(_ int, v T) error {
+		if !other.Has(v) {
+			isSubset = false
+			return errStopRange
+		}
+		return nil
+	})
+	return isSubset
+}
+
+// IsSuperset returns true if every element of other is also a member of o.
+func (o *OrderedSet[T]) IsSuperset(other *OrderedSet[T]) bool {
+	return other.IsSubset(o)
+}
+
+// IsDisjoint returns true if o and other share no elements in common.
+func (o *OrderedSet[T]) IsDisjoint(other *OrderedSet[T]) bool {
+	isDisjoint := true
+	o.Range(func(_ int, v T) error {
+		if other.Has(v) {
+			isDisjoint = false
+			return errStopRange
+		}
+		return nil
+	})
+	return isDisjoint
+}
@@ -0,0 +1,128 @@
+package ordset_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kklash/ordset"
+)
+
+func TestSetOps(t *testing.T) {
+	t.Run("Union", func(t *testing.T) {
+		a := ordset.New[int](1, 2, 3)
+		b := ordset.New[int](3, 4, 5)
+
+		result := a.Union(b).Slice()
+		expected := []int{1, 2, 3, 4, 5}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("unexpected union: %v", result)
+		}
+	})
+
+	t.Run("Intersection", func(t *testing.T) {
+		a := ordset.New[int](1, 2, 3, 4)
+		b := ordset.New[int](4, 2, 99)
+
+		result := a.Intersection(b).Slice()
+		expected := []int{2, 4}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("unexpected intersection: %v", result)
+		}
+	})
+
+	t.Run("Difference", func(t *testing.T) {
+		a := ordset.New[int](1, 2, 3, 4)
+		b := ordset.New[int](2, 4)
+
+		result := a.Difference(b).Slice()
+		expected := []int{1, 3}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("unexpected difference: %v", result)
+		}
+	})
+
+	t.Run("SymmetricDifference", func(t *testing.T) {
+		a := ordset.New[int](1, 2, 3)
+		b := ordset.New[int](3, 4, 5)
+
+		result := a.SymmetricDifference(b).Slice()
+		expected := []int{1, 2, 4, 5}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("unexpected symmetric difference: %v", result)
+		}
+	})
+
+	t.Run("AddAll", func(t *testing.T) {
+		a := ordset.New[int](1, 2)
+		b := ordset.New[int](2, 3)
+
+		a.AddAll(b)
+		expected := []int{1, 2, 3}
+		if result := a.Slice(); !reflect.DeepEqual(result, expected) {
+			t.Errorf("unexpected result after AddAll: %v", result)
+		}
+	})
+
+	t.Run("RemoveAll", func(t *testing.T) {
+		a := ordset.New[int](1, 2, 3, 4)
+		b := ordset.New[int](2, 4, 99)
+
+		a.RemoveAll(b)
+		expected := []int{1, 3}
+		if result := a.Slice(); !reflect.DeepEqual(result, expected) {
+			t.Errorf("unexpected result after RemoveAll: %v", result)
+		}
+	})
+
+	t.Run("RetainAll", func(t *testing.T) {
+		a := ordset.New[int](1, 2, 3, 4)
+		b := ordset.New[int](2, 4, 99)
+
+		a.RetainAll(b)
+		expected := []int{2, 4}
+		if result := a.Slice(); !reflect.DeepEqual(result, expected) {
+			t.Errorf("unexpected result after RetainAll: %v", result)
+		}
+	})
+
+	t.Run("Equal", func(t *testing.T) {
+		a := ordset.New[int](1, 2, 3)
+		b := ordset.New[int](3, 2, 1)
+		c := ordset.New[int](1, 2)
+
+		if !a.Equal(b) {
+			t.Errorf("expected a to equal b")
+		}
+		if a.Equal(c) {
+			t.Errorf("expected a not to equal c")
+		}
+	})
+
+	t.Run("IsSubset/IsSuperset", func(t *testing.T) {
+		a := ordset.New[int](1, 2)
+		b := ordset.New[int](1, 2, 3)
+
+		if !a.IsSubset(b) {
+			t.Errorf("expected a to be a subset of b")
+		}
+		if a.IsSuperset(b) {
+			t.Errorf("expected a not to be a superset of b")
+		}
+		if !b.IsSuperset(a) {
+			t.Errorf("expected b to be a superset of a")
+		}
+	})
+
+	t.Run("IsDisjoint", func(t *testing.T) {
+		a := ordset.New[int](1, 2)
+		b := ordset.New[int](3, 4)
+		c := ordset.New[int](2, 5)
+
+		if !a.IsDisjoint(b) {
+			t.Errorf("expected a and b to be disjoint")
+		}
+		if a.IsDisjoint(c) {
+			t.Errorf("expected a and c not to be disjoint")
+		}
+	})
+}
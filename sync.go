@@ -0,0 +1,147 @@
+package ordset
+
+import "sync"
+
+// SyncOrderedSet wraps an OrderedSet with a sync.RWMutex, making it safe for concurrent use by
+// multiple goroutines. The plain OrderedSet remains lock-free; reach for SyncOrderedSet only
+// once a set is actually shared across goroutines.
+type SyncOrderedSet[T comparable] struct {
+	mu  sync.RWMutex
+	set *OrderedSet[T]
+}
+
+// NewSync initializes a SyncOrderedSet of element type T with an initial set of elements.
+func NewSync[T comparable](elems ...T) *SyncOrderedSet[T] {
+	return &SyncOrderedSet[T]{set: New[T](elems...)}
+}
+
+// Len returns the number of elements in the SyncOrderedSet.
+func (s *SyncOrderedSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Len()
+}
+
+// Has returns true if the given value v is a member of the SyncOrderedSet.
+func (s *SyncOrderedSet[T]) Has(v T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Has(v)
+}
+
+// Front returns the element at the front of the SyncOrderedSet.
+func (s *SyncOrderedSet[T]) Front() T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Front()
+}
+
+// Back returns the element at the back of the SyncOrderedSet.
+func (s *SyncOrderedSet[T]) Back() T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Back()
+}
+
+// Append pushes a value to the back of the SyncOrderedSet.
+func (s *SyncOrderedSet[T]) Append(v T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Append(v)
+}
+
+// Prepend pushes a value to the front of the SyncOrderedSet.
+func (s *SyncOrderedSet[T]) Prepend(v T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Prepend(v)
+}
+
+// Pop extracts and removes a value from the right of the SyncOrderedSet.
+func (s *SyncOrderedSet[T]) Pop() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Pop()
+}
+
+// Shift extracts and removes a value from the left of the SyncOrderedSet.
+func (s *SyncOrderedSet[T]) Shift() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Shift()
+}
+
+// Insert inserts the given value v into the SyncOrderedSet at a specific position relative to
+// the given mark value. See OrderedSet.Insert for the full semantics.
+func (s *SyncOrderedSet[T]) Insert(v, mark T, after bool) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Insert(v, mark, after)
+}
+
+// Move repositions the set element value v relative to the given mark value. See
+// OrderedSet.Move for the full semantics.
+func (s *SyncOrderedSet[T]) Move(v, mark T, after bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Move(v, mark, after)
+}
+
+// Remove removes the value v from the SyncOrderedSet, if present.
+func (s *SyncOrderedSet[T]) Remove(v T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Remove(v)
+}
+
+// Range calls loop once for every element, front to back, while holding a read lock for the
+// duration of the call. loop must not call back into s, or it will deadlock; use RangeSnapshot
+// if that's required.
+func (s *SyncOrderedSet[T]) Range(loop func(int, T) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Range(loop)
+}
+
+// RangeReverse calls loop once for every element, back to front, while holding a read lock for
+// the duration of the call. The same restriction on loop as Range applies.
+func (s *SyncOrderedSet[T]) RangeReverse(loop func(int, T) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.RangeReverse(loop)
+}
+
+// Slice returns a point-in-time copy of the SyncOrderedSet's elements, front to back.
+func (s *SyncOrderedSet[T]) Slice() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Slice()
+}
+
+// GetOrAppend appends v if it is not already a member, returning whether it was added. This
+// happens under a single write lock, unlike calling Has followed by Append.
+func (s *SyncOrderedSet[T]) GetOrAppend(v T) (added bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Append(v)
+}
+
+// RangeSnapshot calls loop once for every element of a point-in-time copy of the set, without
+// holding any lock during the calls. This makes it safe for loop to call back into s.
+func (s *SyncOrderedSet[T]) RangeSnapshot(loop func(int, T) error) error {
+	for i, v := range s.Slice() {
+		if err := loop(i, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Transaction calls fn with direct, lock-free access to the underlying OrderedSet, holding the
+// write lock for fn's entire duration. This allows grouping several mutations into a single
+// atomic operation.
+func (s *SyncOrderedSet[T]) Transaction(fn func(*OrderedSet[T]) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.set)
+}
@@ -0,0 +1,102 @@
+package ordset_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/kklash/ordset"
+)
+
+func TestSyncOrderedSet(t *testing.T) {
+	t.Run("Append/Has/Len", func(t *testing.T) {
+		set := ordset.NewSync[int]()
+
+		if !set.Append(1) || !set.Append(2) {
+			t.Errorf("expected appends to return true")
+			return
+		}
+		if set.Append(1) {
+			t.Errorf("expected re-append of 1 to return false")
+			return
+		}
+		if !set.Has(2) || set.Len() != 2 {
+			t.Errorf("unexpected state after appends: has(2)=%v len=%d", set.Has(2), set.Len())
+			return
+		}
+	})
+
+	t.Run("GetOrAppend", func(t *testing.T) {
+		set := ordset.NewSync[int](1)
+
+		if set.GetOrAppend(1) {
+			t.Errorf("expected GetOrAppend of existing member to return false")
+			return
+		}
+		if !set.GetOrAppend(2) {
+			t.Errorf("expected GetOrAppend of new member to return true")
+			return
+		}
+		if !reflect.DeepEqual(set.Slice(), []int{1, 2}) {
+			t.Errorf("unexpected slice: %v", set.Slice())
+			return
+		}
+	})
+
+	t.Run("RangeSnapshot allows reentrant calls", func(t *testing.T) {
+		set := ordset.NewSync[int](1, 2, 3)
+
+		var seen []int
+		err := set.RangeSnapshot(func(_ int, v int) error {
+			seen = append(seen, v)
+			set.Append(v * 10) // would deadlock under Range
+			return nil
+		})
+		if err != nil {
+			t.Errorf("unexpected error from RangeSnapshot: %s", err)
+			return
+		}
+		if !reflect.DeepEqual(seen, []int{1, 2, 3}) {
+			t.Errorf("unexpected snapshot contents: %v", seen)
+			return
+		}
+	})
+
+	t.Run("Transaction groups mutations under one lock", func(t *testing.T) {
+		set := ordset.NewSync[int](1, 2)
+
+		err := set.Transaction(func(inner *ordset.OrderedSet[int]) error {
+			inner.Append(3)
+			inner.Remove(1)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("unexpected error from Transaction: %s", err)
+			return
+		}
+		if !reflect.DeepEqual(set.Slice(), []int{2, 3}) {
+			t.Errorf("unexpected slice after transaction: %v", set.Slice())
+			return
+		}
+	})
+
+	t.Run("concurrent Append/Has is race-free", func(t *testing.T) {
+		set := ordset.NewSync[int]()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(v int) {
+				defer wg.Done()
+				set.Append(v)
+				set.Has(v)
+			}(i)
+		}
+		wg.Wait()
+
+		if set.Len() != 100 {
+			t.Errorf("expected 100 elements, got %d", set.Len())
+			return
+		}
+	})
+}